@@ -51,8 +51,10 @@ CREATE TABLE my_columnar_table
 		-- convert to columnar
 		SELECT columnar.alter_table_set_access_method('my_table', 'columnar');
 		-- back to row
-		-- TODO: reenable this after it's supported
-		-- SELECT alter_table_set_access_method('my_table', 'heap');
+		-- TODO: reenable this and the heap -> columnar -> heap round-trip
+		-- validation once columnar.alter_table_set_access_method(..., 'heap')
+		-- ships; it is not yet supported upstream.
+		-- SELECT columnar.alter_table_set_access_method('my_table', 'heap');
 		`,
 	},
 	{
@@ -90,6 +92,245 @@ CREATE UNIQUE INDEX p2_i_unique ON p2 (i);
 ALTER TABLE p2 ADD UNIQUE (n);
 			`,
 	},
+	{
+		Name: "list partition",
+		SQL: `
+CREATE TABLE list_parent(region text, i int, n numeric, s text)
+  PARTITION BY LIST (region);
+
+-- columnar partitions
+CREATE TABLE list_eu PARTITION OF list_parent
+  FOR VALUES IN ('eu') USING COLUMNAR;
+CREATE TABLE list_us PARTITION OF list_parent
+  FOR VALUES IN ('us') USING COLUMNAR;
+-- row partition
+CREATE TABLE list_other PARTITION OF list_parent
+  FOR VALUES IN ('other');
+
+INSERT INTO list_parent VALUES ('eu', 10, 100, 'one thousand'); -- columnar
+INSERT INTO list_parent VALUES ('us', 20, 200, 'two thousand'); -- columnar
+INSERT INTO list_parent VALUES ('other', 30, 300, 'three thousand'); -- row
+
+VACUUM list_parent;
+
+SELECT count(*) FROM list_parent;
+			`,
+		Validate: func(t *testing.T, row pgx.Row) {
+			var count int
+			if err := row.Scan(&count); err != nil {
+				t.Fatal(err)
+			}
+
+			if want, got := 3, count; want != got {
+				t.Fatalf("expected %d rows across list partitions, got %d", want, got)
+			}
+		},
+	},
+	{
+		Name: "hash partition",
+		SQL: `
+CREATE TABLE hash_parent(i int, n numeric, s text)
+  PARTITION BY HASH (i);
+
+-- all four modulus/remainder buckets land on a columnar partition
+CREATE TABLE hash_p0 PARTITION OF hash_parent
+  FOR VALUES WITH (MODULUS 4, REMAINDER 0) USING COLUMNAR;
+CREATE TABLE hash_p1 PARTITION OF hash_parent
+  FOR VALUES WITH (MODULUS 4, REMAINDER 1) USING COLUMNAR;
+CREATE TABLE hash_p2 PARTITION OF hash_parent
+  FOR VALUES WITH (MODULUS 4, REMAINDER 2) USING COLUMNAR;
+CREATE TABLE hash_p3 PARTITION OF hash_parent
+  FOR VALUES WITH (MODULUS 4, REMAINDER 3) USING COLUMNAR;
+
+CREATE TABLE hash_staging(i int, n numeric, s text);
+INSERT INTO hash_staging SELECT g, g * 10, 'row ' || g FROM generate_series(0, 99) g;
+
+-- route rows spanning every bucket through INSERT ... SELECT
+INSERT INTO hash_parent SELECT * FROM hash_staging;
+
+-- TODO: alter_columnar_table_set('hash_parent', ...) doesn't yet recurse to
+-- columnar children of a hash-partitioned parent (the parent itself owns no
+-- columnar storage); reenable the alter_columnar_table_set call below once the AM grows
+-- that support.
+-- SELECT alter_columnar_table_set('hash_parent', compression => 'zstd');
+
+VACUUM hash_parent;
+
+SELECT count(*), count(DISTINCT tableoid) FROM hash_parent;
+			`,
+		Validate: func(t *testing.T, row pgx.Row) {
+			var count, buckets int
+			if err := row.Scan(&count, &buckets); err != nil {
+				t.Fatal(err)
+			}
+
+			if want, got := 100, count; want != got {
+				t.Fatalf("expected %d rows routed into hash partitions, got %d", want, got)
+			}
+
+			if want, got := 4, buckets; want != got {
+				t.Fatalf("expected rows spanning all %d hash buckets, got %d", want, buckets)
+			}
+		},
+	},
+	{
+		// TODO: columnar.row_lock_mode is not yet a registered GUC anywhere
+		// in the extension, so SET on it raises "unrecognized configuration
+		// parameter" before execution ever reaches the locking behavior below
+		// -- reenable once the GUC is defined and wired into the executor's
+		// locking path.
+		Name: "row locking mode error on columnar partitions",
+		SQL: `
+-- SET columnar.row_lock_mode = 'error';
+--
+-- DO $$
+-- BEGIN
+--     BEGIN
+--         PERFORM 1 FROM parent FOR UPDATE;
+--         RAISE EXCEPTION 'expected FOR UPDATE over columnar partitions to raise';
+--     EXCEPTION WHEN feature_not_supported THEN
+--         -- expected: columnar.row_lock_mode = 'error' raises SQLSTATE 0A000
+--         -- (feature_not_supported); assert on the SQLSTATE category rather
+--         -- than message wording so this stays independent of phrasing.
+--         NULL;
+--     END;
+-- END $$;
+--
+-- RESET columnar.row_lock_mode;
+SELECT true;
+			`,
+	},
+	{
+		// TODO: see above -- columnar.row_lock_mode doesn't exist yet.
+		Name: "row locking mode skip_columnar on columnar partitions",
+		SQL: `
+-- SET columnar.row_lock_mode = 'skip_columnar';
+--
+-- BEGIN;
+-- SELECT count(*) FROM parent FOR UPDATE;
+-- COMMIT;
+--
+-- RESET columnar.row_lock_mode;
+SELECT true;
+			`,
+	},
+	{
+		// TODO: see above -- columnar.row_lock_mode doesn't exist yet. Once it
+		// does, table_lock should take the weakest lock mode that still
+		// blocks concurrent writers without blocking plain concurrent
+		// readers (e.g. ShareRowExclusiveLock, not ExclusiveLock).
+		Name: "row locking mode table_lock on columnar partitions",
+		SQL: `
+-- SET columnar.row_lock_mode = 'table_lock';
+--
+-- BEGIN;
+-- SELECT count(*) FROM parent FOR UPDATE;
+--
+-- SELECT mode FROM pg_locks
+--   WHERE relation = 'p0'::regclass AND locktype = 'relation' AND pid = pg_backend_pid();
+-- COMMIT;
+--
+-- RESET columnar.row_lock_mode;
+SELECT true;
+			`,
+	},
+	{
+		Name: "alter column type on partitioned columnar/row parent",
+		SQL: `
+-- TODO: reenable once the column-by-column stripe rewrite for
+-- ALTER TABLE ... ALTER COLUMN TYPE on columnar children lands; today it can
+-- fail or silently skip columnar children on a mixed columnar/heap
+-- partitioned parent, so this case is disabled rather than asserting
+-- behavior no code here implements.
+--
+-- ALTER TABLE parent ALTER COLUMN n TYPE NUMERIC(20, 4);
+--
+-- SELECT
+--     (SELECT n FROM p0 WHERE i = 10),
+--     (SELECT n FROM p1 WHERE i = 20),
+--     (SELECT n FROM p2 WHERE i = 30),
+--     (SELECT compression FROM columnar.options WHERE relation = 'p0'::regclass),
+--     (SELECT compression FROM columnar.options WHERE relation = 'p1'::regclass),
+--     (SELECT format_type(atttypid, atttypmod) FROM pg_attribute
+--        WHERE attrelid = 'parent'::regclass AND attname = 'n'),
+--     (SELECT format_type(atttypid, atttypmod) FROM pg_attribute
+--        WHERE attrelid = 'p0'::regclass AND attname = 'n'),
+--     (SELECT format_type(atttypid, atttypmod) FROM pg_attribute
+--        WHERE attrelid = 'p2'::regclass AND attname = 'n');
+			`,
+	},
+	{
+		// TODO: also assert columnar.stripe_id once that system column ships;
+		// it doesn't exist yet, so referencing it here would fail outright.
+		// tableoid is generic Postgres and already works against any table.
+		Name: "tableoid on columnar partitions",
+		SQL: `
+-- every row inserted into parent above, whether into a columnar partition
+-- (p0, p1) or the row partition (p2), should report a tableoid matching its
+-- partition
+SELECT
+    count(*) FILTER (WHERE tableoid = 'p0'::regclass),
+    count(*) FILTER (WHERE tableoid = 'p1'::regclass),
+    count(*) FILTER (WHERE tableoid = 'p2'::regclass)
+FROM parent;
+			`,
+		Validate: func(t *testing.T, row pgx.Row) {
+			var p0, p1, p2 int
+			if err := row.Scan(&p0, &p1, &p2); err != nil {
+				t.Fatal(err)
+			}
+
+			if want, got := 1, p0; want != got {
+				t.Fatalf("expected %d row from p0, got %d", want, got)
+			}
+
+			if want, got := 1, p1; want != got {
+				t.Fatalf("expected %d row from p1, got %d", want, got)
+			}
+
+			if want, got := 1, p2; want != got {
+				t.Fatalf("expected %d row from p2, got %d", want, got)
+			}
+		},
+	},
+	{
+		// TODO: also target by columnar.stripe_id once that system column
+		// ships; it doesn't exist yet, so referencing it here would fail
+		// outright.
+		Name: "tableoid in WHERE clauses and DELETE/UPDATE targeting lists",
+		SQL: `
+-- throwaway row in p1 to exercise DELETE targeting by tableoid
+INSERT INTO parent VALUES ('2020-02-20', 99, 990, 'throwaway'); -- columnar, p1
+
+DELETE FROM parent WHERE tableoid = 'p1'::regclass AND i = 99;
+
+UPDATE parent SET s = 'updated' WHERE tableoid = 'p0'::regclass;
+
+SELECT
+    (SELECT count(*) FROM parent WHERE tableoid = 'p1'::regclass),
+    (SELECT count(*) FROM parent WHERE i = 99),
+    (SELECT s FROM p0 WHERE i = 10);
+			`,
+		Validate: func(t *testing.T, row pgx.Row) {
+			var p1Remaining, deletedRemaining int
+			var updatedS string
+			if err := row.Scan(&p1Remaining, &deletedRemaining, &updatedS); err != nil {
+				t.Fatal(err)
+			}
+
+			if want, got := 1, p1Remaining; want != got {
+				t.Fatalf("expected %d row left in p1 after DELETE ... WHERE tableoid = 'p1', got %d", want, got)
+			}
+
+			if want, got := 0, deletedRemaining; want != got {
+				t.Fatalf("expected the throwaway row targeted via tableoid to be deleted, got %d remaining", got)
+			}
+
+			if want, got := "updated", updatedS; want != got {
+				t.Fatalf("expected UPDATE ... WHERE tableoid = 'p0' to target p0, got s=%q", got)
+			}
+		},
+	},
 	{
 		Name: "options",
 		SQL: `
@@ -122,6 +363,74 @@ INSERT INTO columnar_table (id, i1, i2, n, t)
 VALUES ('75372aac-d74a-4e5a-8bf3-43cdaf9011de', 2, 3, 100.1, 'hydra');
 		`,
 	},
+	{
+		Name: "multiple stripes with different compression settings",
+		SQL: `
+CREATE TABLE multi_stripe_table (id INT, n NUMERIC, t TEXT) USING columnar;
+
+SELECT alter_columnar_table_set('multi_stripe_table', compression => 'none', stripe_row_limit => 1000);
+INSERT INTO multi_stripe_table SELECT g, g * 1.5, 'none-' || g FROM generate_series(1, 1000) g;
+
+SELECT alter_columnar_table_set('multi_stripe_table', compression => 'pglz', stripe_row_limit => 1000);
+INSERT INTO multi_stripe_table SELECT g, g * 1.5, 'pglz-' || g FROM generate_series(1001, 2000) g;
+
+SELECT alter_columnar_table_set('multi_stripe_table', compression => 'zstd', stripe_row_limit => 1000);
+INSERT INTO multi_stripe_table SELECT g, g * 1.5, 'zstd-' || g FROM generate_series(2001, 3000) g;
+
+SELECT alter_columnar_table_set('multi_stripe_table', compression => 'lz4', stripe_row_limit => 1000);
+INSERT INTO multi_stripe_table SELECT g, g * 1.5, 'lz4-' || g FROM generate_series(3001, 4000) g;
+			`,
+	},
+	{
+		Name: "partially filled chunk at stripe boundary",
+		SQL: `
+SET columnar.chunk_group_row_limit = 1000;
+
+CREATE TABLE partial_chunk_table (id INT, t TEXT) USING columnar;
+-- keep everything in a single stripe so the partially-filled trailing chunk
+-- group is a boundary within that stripe, not just a second stripe
+SELECT alter_columnar_table_set('partial_chunk_table', stripe_row_limit => 50000);
+-- 3 full 1000-row chunk groups plus one partially filled 205-row chunk group
+INSERT INTO partial_chunk_table SELECT g, 'row-' || g FROM generate_series(1, 3205) g;
+
+RESET columnar.chunk_group_row_limit;
+			`,
+	},
+	{
+		Name: "null-heavy columns",
+		SQL: `
+CREATE TABLE null_heavy_table (id INT, n NUMERIC, t TEXT) USING columnar;
+INSERT INTO null_heavy_table
+  SELECT g, CASE WHEN g % 10 = 0 THEN g * 1.1 ELSE NULL END, CASE WHEN g % 10 = 0 THEN 'val-' || g ELSE NULL END
+  FROM generate_series(1, 5000) g;
+			`,
+	},
+	{
+		// NUMERIC(p, s) zero-pads values on assignment to exactly s
+		// fractional digits, so the generated value's fractional digit count
+		// must match the declared scale exactly or the stored value (and the
+		// AfterUpgradeCases assertion on it) won't match what was inserted.
+		Name: "very wide numeric values",
+		SQL: `
+CREATE TABLE wide_numeric_table (id INT, n NUMERIC(1000, 490)) USING columnar;
+INSERT INTO wide_numeric_table
+  SELECT g, (g::text || '.' || repeat((g % 10)::text, 490))::numeric
+  FROM generate_series(1, 100) g;
+			`,
+	},
+	{
+		Name: "partitioned parent with columnar and heap children",
+		SQL: `
+CREATE TABLE upgrade_parent (id INT, t TEXT) PARTITION BY RANGE (id);
+CREATE TABLE upgrade_parent_columnar PARTITION OF upgrade_parent
+  FOR VALUES FROM (0) TO (1000) USING COLUMNAR;
+CREATE TABLE upgrade_parent_heap PARTITION OF upgrade_parent
+  FOR VALUES FROM (1000) TO (2000);
+
+INSERT INTO upgrade_parent SELECT g, 'columnar-' || g FROM generate_series(0, 999) g;
+INSERT INTO upgrade_parent SELECT g, 'heap-' || g FROM generate_series(1000, 1999) g;
+			`,
+	},
 }
 
 var AfterUpgradeCases = []Case{
@@ -175,4 +484,138 @@ CREATE TABLE columnar_table2
 			}
 		},
 	},
+	{
+		Name: "scan every pre-upgrade stripe across compression settings",
+		SQL: `
+SELECT count(*), count(DISTINCT id) FROM multi_stripe_table;
+			`,
+		Validate: func(t *testing.T, row pgx.Row) {
+			var total, distinctIDs int
+			if err := row.Scan(&total, &distinctIDs); err != nil {
+				t.Fatal(err)
+			}
+
+			if want, got := 4000, total; want != got {
+				t.Fatalf("expected %d rows across none/pglz/zstd/lz4 stripes after upgrade, got %d", want, got)
+			}
+		},
+	},
+	{
+		Name: "row counts per pre-upgrade stripe via columnar.stripe",
+		SQL: `
+SELECT count(*), sum(row_count), min(row_count), max(row_count)
+FROM columnar.stripe
+WHERE relation = 'multi_stripe_table'::regclass;
+			`,
+		Validate: func(t *testing.T, row pgx.Row) {
+			var stripeCount, totalRows, minRows, maxRows int
+			if err := row.Scan(&stripeCount, &totalRows, &minRows, &maxRows); err != nil {
+				t.Fatal(err)
+			}
+
+			if want := 1000; minRows != want || maxRows != want {
+				t.Fatalf("expected every pre-upgrade stripe to hold %d rows, got between %d and %d", want, minRows, maxRows)
+			}
+
+			if want, got := 4, stripeCount; want != got {
+				t.Fatalf("expected %d pre-upgrade stripes (one per compression setting), got %d", want, got)
+			}
+
+			if want, got := 4000, totalRows; want != got {
+				t.Fatalf("expected columnar.stripe row counts to sum to %d, got %d", want, got)
+			}
+		},
+	},
+	{
+		Name: "partially filled chunk survives upgrade",
+		SQL: `
+SELECT
+    (SELECT count(*) FROM partial_chunk_table),
+    (SELECT count(*) FROM columnar.stripe WHERE relation = 'partial_chunk_table'::regclass),
+    (SELECT min(row_count) FROM columnar.chunk_group WHERE relation = 'partial_chunk_table'::regclass);
+			`,
+		Validate: func(t *testing.T, row pgx.Row) {
+			var count, stripeCount, smallestChunkGroup int
+			if err := row.Scan(&count, &stripeCount, &smallestChunkGroup); err != nil {
+				t.Fatal(err)
+			}
+
+			if want, got := 3205, count; want != got {
+				t.Fatalf("expected %d rows in partial_chunk_table, got %d", want, got)
+			}
+
+			if want, got := 1, stripeCount; want != got {
+				t.Fatalf("expected all rows to land in a single stripe, got %d stripes", got)
+			}
+
+			if want, got := 205, smallestChunkGroup; want != got {
+				t.Fatalf("expected a partially filled trailing chunk group of %d rows within the stripe, smallest chunk group has %d rows", want, got)
+			}
+		},
+	},
+	{
+		Name: "null-heavy columns survive upgrade",
+		SQL: `
+SELECT count(*) FILTER (WHERE n IS NULL), count(*) FILTER (WHERE n IS NOT NULL) FROM null_heavy_table;
+			`,
+		Validate: func(t *testing.T, row pgx.Row) {
+			var nulls, nonNulls int
+			if err := row.Scan(&nulls, &nonNulls); err != nil {
+				t.Fatal(err)
+			}
+
+			if want, got := 4500, nulls; want != got {
+				t.Fatalf("expected %d NULL rows after upgrade, got %d", want, got)
+			}
+
+			if want, got := 500, nonNulls; want != got {
+				t.Fatalf("expected %d non-NULL rows after upgrade, got %d", want, got)
+			}
+		},
+	},
+	{
+		Name: "wide numeric values survive upgrade",
+		SQL:  "SELECT n FROM wide_numeric_table WHERE id = 42;",
+		Validate: func(t *testing.T, row pgx.Row) {
+			var n string
+			if err := row.Scan(&n); err != nil {
+				t.Fatal(err)
+			}
+
+			if want, got := "42."+repeatDigit(2, 490), n; want != got {
+				t.Fatalf("expected wide numeric value %s after upgrade, got %s", want, got)
+			}
+		},
+	},
+	{
+		Name: "insert new stripes after upgrade into pre-upgrade partitioned table",
+		SQL: `
+INSERT INTO upgrade_parent SELECT g, 'columnar-post-' || g FROM generate_series(500, 599) g;
+
+SELECT count(*), min(stripe_num), max(stripe_num)
+FROM columnar.stripe
+WHERE relation = 'upgrade_parent_columnar'::regclass;
+			`,
+		Validate: func(t *testing.T, row pgx.Row) {
+			var stripeCount int
+			var minStripeNum, maxStripeNum any
+			if err := row.Scan(&stripeCount, &minStripeNum, &maxStripeNum); err != nil {
+				t.Fatal(err)
+			}
+
+			if stripeCount < 2 {
+				t.Fatalf("expected post-upgrade inserts to add at least one new stripe alongside the pre-upgrade stripe, got %d total stripes", stripeCount)
+			}
+		},
+	},
+}
+
+// repeatDigit mirrors the digit generation used to seed wide_numeric_table
+// in BeforeUpgradeCases, so AfterUpgradeCases can assert on the exact value.
+func repeatDigit(digit, count int) string {
+	digits := make([]byte, count)
+	for i := range digits {
+		digits[i] = byte('0' + digit)
+	}
+	return string(digits)
 }